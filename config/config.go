@@ -0,0 +1,86 @@
+package config
+
+// KeyType selects where GreenfieldConfig's signing key material is read from.
+type KeyType string
+
+const (
+	// KeyTypeLocalPrivateKey reads a plaintext hex private key straight out of
+	// GreenfieldConfig.PrivateKey. This is the default when KeyType is left unset.
+	KeyTypeLocalPrivateKey KeyType = "local"
+	// KeyTypeAWSPrivateKey fetches a JSON-encoded private key blob from AWS Secrets
+	// Manager, using AWSSecretName/AWSRegion.
+	KeyTypeAWSPrivateKey KeyType = "awssecret"
+	// KeyTypeGCPSecretManager fetches a JSON-encoded private key blob from GCP Secret
+	// Manager, using GCPProjectId/GCPSecretName.
+	KeyTypeGCPSecretManager KeyType = "gcp"
+	// KeyTypeVault signs through a HashiCorp Vault Transit engine, using
+	// VaultAddress/VaultToken/VaultTransitKeyName. The key never leaves Vault.
+	KeyTypeVault KeyType = "vault"
+	// KeyTypeRemoteSigner delegates signing to a co-located process speaking this
+	// package's own JSON-over-TCP remote-signer protocol, using RemoteSignerAddress.
+	// This is a bespoke protocol, not tendermint's privval socket protocol, and is not
+	// wire-compatible with tmkms or other privval-speaking signers.
+	KeyTypeRemoteSigner KeyType = "remote_signer"
+)
+
+// Config is the root configuration for the greenfield-challenger.
+type Config struct {
+	GreenfieldConfig GreenfieldConfig `json:"greenfield_config"`
+	AlertConfig      AlertConfig      `json:"alert_config"`
+	MetricsConfig    MetricsConfig    `json:"metrics_config"`
+}
+
+// GreenfieldConfig holds everything Executor needs to talk to and sign for the
+// greenfield chain.
+type GreenfieldConfig struct {
+	// RPCAddrs and GRPCAddrs are parallel lists of data seed endpoints - RPCAddrs[i]
+	// and GRPCAddrs[i] must point at the same node.
+	RPCAddrs  []string `json:"rpc_addrs"`
+	GRPCAddrs []string `json:"grpc_addrs"`
+
+	// KeyType selects how the signing key below is obtained.
+	KeyType KeyType `json:"key_type"`
+
+	// PrivateKey is the hex-encoded private key, used when KeyType is
+	// KeyTypeLocalPrivateKey (or unset).
+	PrivateKey string `json:"private_key"`
+
+	// AWSSecretName/AWSRegion locate the private key when KeyType is
+	// KeyTypeAWSPrivateKey.
+	AWSSecretName string `json:"aws_secret_name"`
+	AWSRegion     string `json:"aws_region"`
+
+	// GCPProjectId/GCPSecretName locate the private key when KeyType is
+	// KeyTypeGCPSecretManager.
+	GCPProjectId  string `json:"gcp_project_id"`
+	GCPSecretName string `json:"gcp_secret_name"`
+
+	// VaultAddress/VaultToken/VaultTransitKeyName configure the Transit engine used
+	// when KeyType is KeyTypeVault.
+	VaultAddress        string `json:"vault_address"`
+	VaultToken          string `json:"vault_token"`
+	VaultTransitKeyName string `json:"vault_transit_key_name"`
+
+	// RemoteSignerAddress is the host:port of the remote signer used when KeyType is
+	// KeyTypeRemoteSigner.
+	RemoteSignerAddress string `json:"remote_signer_address"`
+
+	// BlockTimeSkewThreshold is how many seconds a data seed's reported block time
+	// may lag real wall-clock time before UpdateClientLoop treats it as stalled. A
+	// value <= 0 falls back to the executor package's default.
+	BlockTimeSkewThreshold int64 `json:"block_time_skew_threshold"`
+}
+
+// AlertConfig configures the (currently disabled) Telegram alerting used when a data
+// seed falls out of service.
+type AlertConfig struct {
+	Identity       string `json:"identity"`
+	TelegramBotId  string `json:"telegram_bot_id"`
+	TelegramChatId string `json:"telegram_chat_id"`
+}
+
+// MetricsConfig configures the executor's Prometheus/healthz HTTP server. Leaving
+// Address empty disables the server entirely.
+type MetricsConfig struct {
+	Address string `json:"address"`
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	secretmanagerpb "google.golang.org/genproto/googleapis/cloud/secretmanager/v1"
+)
+
+// GetSecret fetches the raw secret string named secretName from AWS Secrets Manager in
+// region.
+func GetSecret(secretName, region string) (string, error) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return "", err
+	}
+	svc := secretsmanager.New(sess)
+	result, err := svc.GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretName),
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(result.SecretString), nil
+}
+
+// GetGCPSecret fetches the latest version of secretName from GCP Secret Manager in
+// project projectId.
+func GetGCPSecret(projectId, secretName string) (string, error) {
+	ctx := context.Background()
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	result, err := client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: fmt.Sprintf("projects/%s/secrets/%s/versions/latest", projectId, secretName),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(result.Payload.Data), nil
+}
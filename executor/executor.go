@@ -3,30 +3,61 @@ package executor
 import (
 	"context"
 	"encoding/hex"
-	"encoding/json"
-	_ "encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/bnb-chain/gnfd-challenger/common"
 	"github.com/bnb-chain/gnfd-challenger/config"
 	"github.com/bnb-chain/gnfd-challenger/logging"
+	"github.com/bnb-chain/gnfd-challenger/metrics"
 	"github.com/cosmos/cosmos-sdk/codec"
 
 	"github.com/avast/retry-go/v4"
 	"github.com/cosmos/cosmos-sdk/types/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
-	"github.com/evmos/ethermint/crypto/ethsecp256k1"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	"github.com/tendermint/tendermint/rpc/client/http"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	libclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
 	tmtypes "github.com/tendermint/tendermint/types"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
+const (
+	// clientFailureThreshold is the number of consecutive retryable failures a data
+	// seed can accumulate before it is quarantined from the backup pool for a while.
+	clientFailureThreshold = 3
+	// clientQuarantineDuration is how long a data seed is skipped by withClient once
+	// it crosses clientFailureThreshold.
+	clientQuarantineDuration = 30 * time.Second
+	// validatorsPerPage is the page size used to page through the Validators RPC,
+	// which the underlying tendermint RPC caps at 100 entries per page regardless
+	// of what is requested.
+	validatorsPerPage = 100
+	// defaultBlockTimeSkewThreshold is used when config.GreenfieldConfig.BlockTimeSkewThreshold
+	// is left unset.
+	defaultBlockTimeSkewThreshold = 60 * time.Second
+	// defaultRequestTimeout bounds every per-call RPC/gRPC request issued through
+	// withClient, so a data seed that hangs rather than erroring still gets cut loose
+	// and fails over to the backup pool instead of blocking forever.
+	defaultRequestTimeout = 5 * time.Second
+)
+
+// blockTimeSkewThreshold returns the configured tolerance for how far a data seed's
+// reported block time may lag real wall-clock time before it is treated as stalled.
+func (e *Executor) blockTimeSkewThreshold() time.Duration {
+	if e.config.GreenfieldConfig.BlockTimeSkewThreshold > 0 {
+		return time.Duration(e.config.GreenfieldConfig.BlockTimeSkewThreshold) * time.Second
+	}
+	return defaultBlockTimeSkewThreshold
+}
+
 type ExecutorClient struct {
 	rpcClient  rpcclient.Client
 	txClient   tx.ServiceClient
@@ -34,6 +65,15 @@ type ExecutorClient struct {
 	Provider   string
 	Height     uint64
 	UpdatedAt  time.Time
+
+	// CatchingUp and LatestBlockTime are the last values reported by this seed's
+	// tendermint Status, used by UpdateClientLoop to avoid promoting a seed that is
+	// still syncing from genesis or whose block production has stalled.
+	CatchingUp      bool
+	LatestBlockTime time.Time
+
+	failureCount     int
+	quarantinedUntil time.Time
 }
 
 type Executor struct {
@@ -41,7 +81,7 @@ type Executor struct {
 	clientIdx         int
 	greenfieldClients []*ExecutorClient
 	config            *config.Config
-	privateKey        *ethsecp256k1.PrivKey
+	signer            Signer
 	address           string
 	validators        []*tmtypes.Validator // used to cache validators
 	cdc               *codec.ProtoCodec
@@ -70,32 +110,6 @@ func NewRpcClient(addr string) *http.HTTP {
 	return rpcClient
 }
 
-func getGreenfieldPrivateKey(cfg *config.GreenfieldConfig) *ethsecp256k1.PrivKey {
-	var privateKey string
-	if cfg.KeyType == config.KeyTypeAWSPrivateKey {
-		result, err := config.GetSecret(cfg.AWSSecretName, cfg.AWSRegion)
-		if err != nil {
-			panic(err)
-		}
-		type AwsPrivateKey struct {
-			PrivateKey string `json:"private_key"`
-		}
-		var awsPrivateKey AwsPrivateKey
-		err = json.Unmarshal([]byte(result), &awsPrivateKey)
-		if err != nil {
-			panic(err)
-		}
-		privateKey = awsPrivateKey.PrivateKey
-	} else {
-		privateKey = cfg.PrivateKey
-	}
-	privKey, err := HexToEthSecp256k1PrivKey(privateKey)
-	if err != nil {
-		panic(err)
-	}
-	return privKey
-}
-
 func initGreenfieldClients(rpcAddrs, grpcAddrs []string) []*ExecutorClient {
 	greenfieldClients := make([]*ExecutorClient, 0)
 
@@ -113,37 +127,151 @@ func initGreenfieldClients(rpcAddrs, grpcAddrs []string) []*ExecutorClient {
 }
 
 func NewGreenfieldExecutor(cfg *config.Config) *Executor {
-	privKey := getGreenfieldPrivateKey(&cfg.GreenfieldConfig)
-	return &Executor{
+	signer, err := NewSigner(&cfg.GreenfieldConfig)
+	if err != nil {
+		panic(err)
+	}
+	e := &Executor{
 		clientIdx:         0,
 		greenfieldClients: initGreenfieldClients(cfg.GreenfieldConfig.RPCAddrs, cfg.GreenfieldConfig.GRPCAddrs),
-		privateKey:        privKey,
-		address:           privKey.PubKey().Address().String(),
+		signer:            signer,
+		address:           signer.Address(),
 		config:            cfg,
 		cdc:               Cdc(),
 	}
+	if cfg.MetricsConfig.Address != "" {
+		metrics.StartServer(cfg.MetricsConfig.Address, e.isSeedPoolHealthy)
+	}
+	return e
 }
 
-func (e *Executor) getRpcClient() rpcclient.Client {
-	e.mutex.RLock()
-	defer e.mutex.RUnlock()
-	return e.greenfieldClients[e.clientIdx].rpcClient
+// isSeedPoolHealthy reports whether at least one configured data seed is neither
+// quarantined nor still catching up, for the /healthz endpoint exposed by the
+// metrics server.
+func (e *Executor) isSeedPoolHealthy() bool {
+	for _, c := range e.rankedClients() {
+		if !e.isQuarantined(c) && !c.CatchingUp {
+			return true
+		}
+	}
+	return false
+}
+
+// isRetryableClientErr reports whether err looks like a transient problem with the
+// endpoint itself (connection lost, deadline blown, seed unavailable) rather than a
+// problem with the request, and is therefore worth retrying against a backup client.
+func isRetryableClientErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded:
+			return true
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "connection refused") ||
+		strings.Contains(msg, "connection reset") ||
+		strings.Contains(msg, "EOF") ||
+		strings.Contains(msg, "deadline exceeded")
 }
 
-func (e *Executor) getTxClient() tx.ServiceClient {
+// rankedClients returns the configured greenfield clients ordered with the currently
+// active client first, followed by the rest of the backup pool in their original order.
+func (e *Executor) rankedClients() []*ExecutorClient {
 	e.mutex.RLock()
 	defer e.mutex.RUnlock()
-	return e.greenfieldClients[e.clientIdx].txClient
+	ranked := make([]*ExecutorClient, 0, len(e.greenfieldClients))
+	ranked = append(ranked, e.greenfieldClients[e.clientIdx])
+	for i := range e.greenfieldClients {
+		if i == e.clientIdx {
+			continue
+		}
+		ranked = append(ranked, e.greenfieldClients[i])
+	}
+	return ranked
 }
 
-func (e *Executor) getAuthClient() authtypes.QueryClient {
+func (e *Executor) isQuarantined(c *ExecutorClient) bool {
 	e.mutex.RLock()
 	defer e.mutex.RUnlock()
-	return e.greenfieldClients[e.clientIdx].authClient
+	return !c.quarantinedUntil.IsZero() && time.Now().Before(c.quarantinedUntil)
+}
+
+func (e *Executor) recordClientFailure(c *ExecutorClient) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	c.failureCount++
+	if c.failureCount >= clientFailureThreshold {
+		c.quarantinedUntil = time.Now().Add(clientQuarantineDuration)
+	}
+}
+
+func (e *Executor) recordClientSuccess(c *ExecutorClient) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	c.failureCount = 0
+	c.quarantinedUntil = time.Time{}
+}
+
+// withClient runs fn against the active greenfield client, falling back through the
+// remaining configured endpoints on a retryable error. Endpoints that fail too many
+// times in a row are quarantined for a while so they're skipped by later calls. The
+// clientIdx rotation performed by UpdateClientLoop is left untouched - this only adds
+// per-call resilience on top of it. method labels the request latency/failure metrics.
+func (e *Executor) withClient(method string, fn func(*ExecutorClient) error) error {
+	var lastErr error
+	attempted := false
+	for _, c := range e.rankedClients() {
+		if e.isQuarantined(c) {
+			continue
+		}
+		attempted = true
+		start := time.Now()
+		lastErr = fn(c)
+		metrics.RequestLatencySeconds.WithLabelValues(c.Provider, method).Observe(time.Since(start).Seconds())
+		if lastErr == nil {
+			e.recordClientSuccess(c)
+			return nil
+		}
+		metrics.RequestFailuresTotal.WithLabelValues(c.Provider, method).Inc()
+		if !isRetryableClientErr(lastErr) {
+			return lastErr
+		}
+		logging.Logger.Errorf("data seed %s failed, falling back to next endpoint, err=%s", c.Provider, lastErr.Error())
+		e.recordClientFailure(c)
+	}
+	if !attempted {
+		return fmt.Errorf("%s: all configured data seeds are quarantined", method)
+	}
+	return lastErr
+}
+
+func (e *Executor) withRpcClient(method string, fn func(rpcclient.Client) error) error {
+	return e.withClient(method, func(c *ExecutorClient) error {
+		return fn(c.rpcClient)
+	})
+}
+
+func (e *Executor) withAuthClient(method string, fn func(authtypes.QueryClient) error) error {
+	return e.withClient(method, func(c *ExecutorClient) error {
+		return fn(c.authClient)
+	})
 }
 
 func (e *Executor) GetBlockResultAtHeight(height int64) (*ctypes.ResultBlockResults, error) {
-	blockResults, err := e.getRpcClient().BlockResults(context.Background(), &height)
+	var blockResults *ctypes.ResultBlockResults
+	err := e.withRpcClient("block_results", func(client rpcclient.Client) error {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+		defer cancel()
+		var err error
+		blockResults, err = client.BlockResults(ctx, &height)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +279,14 @@ func (e *Executor) GetBlockResultAtHeight(height int64) (*ctypes.ResultBlockResu
 }
 
 func (e *Executor) GetBlockAtHeight(height int64) (*tmtypes.Block, error) {
-	block, err := e.getRpcClient().Block(context.Background(), &height)
+	var block *ctypes.ResultBlock
+	err := e.withRpcClient("block", func(client rpcclient.Client) error {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+		defer cancel()
+		var err error
+		block, err = client.Block(ctx, &height)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -159,15 +294,34 @@ func (e *Executor) GetBlockAtHeight(height int64) (*tmtypes.Block, error) {
 }
 
 func (e *Executor) GetLatestBlockHeightWithRetry() (latestHeight uint64, err error) {
-	return e.getLatestBlockHeightWithRetry(e.getRpcClient())
+	err = e.withRpcClient("status", func(client rpcclient.Client) error {
+		var innerErr error
+		latestHeight, innerErr = e.getLatestBlockHeightWithRetry(client)
+		return innerErr
+	})
+	return latestHeight, err
 }
 
 func (e *Executor) getLatestBlockHeightWithRetry(client rpcclient.Client) (latestHeight uint64, err error) {
-	return latestHeight, retry.Do(func() error {
-		latestHeightQueryCtx, cancelLatestHeightQueryCtx := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancelLatestHeightQueryCtx()
+	status, err := e.getSeedStatusWithRetry(client)
+	return status.LatestBlockHeight, err
+}
+
+// seedStatus is the subset of a data seed's tendermint Status that UpdateClientLoop
+// needs to judge whether the seed is fit to be the active client, mirroring what
+// tmservice.GetSyncing exposes upstream.
+type seedStatus struct {
+	LatestBlockHeight uint64
+	LatestBlockTime   time.Time
+	CatchingUp        bool
+}
+
+func (e *Executor) getSeedStatusWithRetry(client rpcclient.Client) (status seedStatus, err error) {
+	return status, retry.Do(func() error {
+		statusQueryCtx, cancelStatusQueryCtx := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelStatusQueryCtx()
 		var err error
-		latestHeight, err = e.getLatestBlockHeight(latestHeightQueryCtx, client)
+		status, err = e.getLatestBlockHeight(statusQueryCtx, client)
 		return err
 	}, common.RtyAttem,
 		common.RtyDelay,
@@ -177,12 +331,26 @@ func (e *Executor) getLatestBlockHeightWithRetry(client rpcclient.Client) (lates
 		}))
 }
 
-func (e *Executor) getLatestBlockHeight(ctx context.Context, client rpcclient.Client) (uint64, error) {
+func (e *Executor) getLatestBlockHeight(ctx context.Context, client rpcclient.Client) (seedStatus, error) {
 	status, err := client.Status(ctx)
 	if err != nil {
-		return 0, err
+		return seedStatus{}, err
 	}
-	return uint64(status.SyncInfo.LatestBlockHeight), nil
+	return seedStatus{
+		LatestBlockHeight: uint64(status.SyncInfo.LatestBlockHeight),
+		LatestBlockTime:   status.SyncInfo.LatestBlockTime,
+		CatchingUp:        status.SyncInfo.CatchingUp,
+	}, nil
+}
+
+// blockTimeSkew returns how far wall-clock time has drifted ahead of c's last reported
+// LatestBlockTime, used to demote a seed whose block production has stalled even though
+// it last reported a plausible height.
+func blockTimeSkew(c *ExecutorClient) time.Duration {
+	if c.LatestBlockTime.IsZero() {
+		return 0
+	}
+	return time.Since(c.LatestBlockTime)
 }
 
 func (e *Executor) UpdateClientLoop() {
@@ -196,45 +364,101 @@ func (e *Executor) UpdateClientLoop() {
 				//config.SendTelegramMessage(e.config.AlertConfig.Identity, e.config.AlertConfig.TelegramBotId,
 				//	e.config.AlertConfig.TelegramChatId, msg)
 			}
-			height, err := e.getLatestBlockHeightWithRetry(greenfieldClient.rpcClient)
+			status, err := e.getSeedStatusWithRetry(greenfieldClient.rpcClient)
 			if err != nil {
 				logging.Logger.Errorf("get latest block height error, err=%s", err.Error())
 				continue
 			}
-			greenfieldClient.Height = height
+			greenfieldClient.Height = status.LatestBlockHeight
+			greenfieldClient.CatchingUp = status.CatchingUp
+			greenfieldClient.LatestBlockTime = status.LatestBlockTime
 			greenfieldClient.UpdatedAt = time.Now()
+			metrics.DataSeedLatestHeight.WithLabelValues(greenfieldClient.Provider).Set(float64(status.LatestBlockHeight))
 		}
 		highestHeight := uint64(0)
-		highestIdx := 0
+		highestIdx := -1
 		for idx := 0; idx < len(e.greenfieldClients); idx++ {
-			if e.greenfieldClients[idx].Height > highestHeight {
-				highestHeight = e.greenfieldClients[idx].Height
+			candidate := e.greenfieldClients[idx]
+			if candidate.CatchingUp {
+				logging.Logger.Infof("data seed %s is still catching up, skipping", candidate.Provider)
+				continue
+			}
+			if skew := blockTimeSkew(candidate); skew > e.blockTimeSkewThreshold() {
+				logging.Logger.Errorf("data seed %s block time lags wall-clock by %s, skipping", candidate.Provider, skew)
+				continue
+			}
+			if candidate.Height > highestHeight {
+				highestHeight = candidate.Height
 				highestIdx = idx
 			}
 		}
+		if highestIdx == -1 {
+			logging.Logger.Errorf("no healthy data seed found, keeping current client")
+			continue
+		}
 		// current ExecutorClient block sync is fall behind, switch to the ExecutorClient with the highest block height
 		if e.greenfieldClients[e.clientIdx].Height+FallBehindThreshold < highestHeight {
 			e.mutex.Lock()
 			e.clientIdx = highestIdx
 			e.mutex.Unlock()
+			metrics.ActiveClientIndex.Set(float64(highestIdx))
 		}
 	}
 }
 
-func (e *Executor) QueryTendermintLightBlock(height int64) ([]byte, error) {
-	validators, err := e.getRpcClient().Validators(context.Background(), &height, nil, nil)
-	commit, err := e.getRpcClient().Commit(context.Background(), &height)
-	if err != nil {
-		return nil, err
+// queryAllValidators pages through the Validators RPC for the given height (nil means
+// latest) until it has collected every entry. If the reported Total changes between
+// pages - a validator-set update landed mid-iteration - it restarts from page 1 against
+// the new total rather than returning a mixed, possibly-inconsistent set.
+func queryAllValidators(client rpcclient.Client, height *int64) ([]*tmtypes.Validator, error) {
+	var all []*tmtypes.Validator
+	page, perPage := 1, validatorsPerPage
+	total := -1
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+		result, err := client.Validators(ctx, height, &page, &perPage)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		if total != -1 && result.Total != total {
+			logging.Logger.Infof("validator set total changed from %d to %d mid-pagination, restarting", total, result.Total)
+			all = all[:0]
+			page = 1
+			total = result.Total
+			continue
+		}
+		total = result.Total
+		all = append(all, result.Validators...)
+		if len(all) >= total {
+			return all, nil
+		}
+		page++
 	}
-	validatorSet := tmtypes.NewValidatorSet(validators.Validators)
+}
+
+func (e *Executor) QueryTendermintLightBlock(height int64) ([]byte, error) {
+	var lightBlock tmtypes.LightBlock
+	err := e.withRpcClient("light_block", func(client rpcclient.Client) error {
+		validators, err := queryAllValidators(client, &height)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+		defer cancel()
+		commit, err := client.Commit(ctx, &height)
+		if err != nil {
+			return err
+		}
+		lightBlock = tmtypes.LightBlock{
+			SignedHeader: &commit.SignedHeader,
+			ValidatorSet: tmtypes.NewValidatorSet(validators),
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	lightBlock := tmtypes.LightBlock{
-		SignedHeader: &commit.SignedHeader,
-		ValidatorSet: validatorSet,
-	}
 	protoBlock, err := lightBlock.ToProto()
 	if err != nil {
 		return nil, err
@@ -243,21 +467,36 @@ func (e *Executor) QueryTendermintLightBlock(height int64) ([]byte, error) {
 }
 
 func (e *Executor) queryLatestValidators() ([]*tmtypes.Validator, error) {
-	validators, err := e.getRpcClient().Validators(context.Background(), nil, nil, nil)
+	var validators []*tmtypes.Validator
+	err := e.withRpcClient("validators_latest", func(client rpcclient.Client) error {
+		result, err := queryAllValidators(client, nil)
+		if err != nil {
+			return err
+		}
+		validators = result
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return validators.Validators, nil
+	return validators, nil
 }
 
 func (e *Executor) QueryValidatorsAtHeight(height uint64) ([]*tmtypes.Validator, error) {
 	atHeight := int64(height)
-	validators, err := e.getRpcClient().Validators(context.Background(), &atHeight, nil, nil)
+	var validators []*tmtypes.Validator
+	err := e.withRpcClient("validators_at_height", func(client rpcclient.Client) error {
+		result, err := queryAllValidators(client, &atHeight)
+		if err != nil {
+			return err
+		}
+		validators = result
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return validators.Validators, nil
-
+	return validators, nil
 }
 
 func (e *Executor) QueryCachedLatestValidators() ([]*tmtypes.Validator, error) {
@@ -280,6 +519,7 @@ func (e *Executor) UpdateCachedLatestValidatorsLoop() {
 			continue
 		}
 		e.validators = validators
+		metrics.CachedValidatorCount.Set(float64(len(validators)))
 	}
 }
 
@@ -296,7 +536,14 @@ func (e *Executor) GetValidatorsBlsPublicKey() ([]string, error) {
 }
 
 func (e *Executor) GetAccount(address string) (authtypes.AccountI, error) {
-	authRes, err := e.getAuthClient().Account(context.Background(), &authtypes.QueryAccountRequest{Address: address})
+	var authRes *authtypes.QueryAccountResponse
+	err := e.withAuthClient("account", func(client authtypes.QueryClient) error {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+		defer cancel()
+		var err error
+		authRes, err = client.Account(ctx, &authtypes.QueryAccountRequest{Address: address})
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -304,5 +551,6 @@ func (e *Executor) GetAccount(address string) (authtypes.AccountI, error) {
 	if err := e.cdc.InterfaceRegistry().UnpackAny(authRes.Account, &account); err != nil {
 		return nil, err
 	}
+	metrics.AccountSequence.WithLabelValues(address).Set(float64(account.GetSequence()))
 	return account, nil
 }
\ No newline at end of file
@@ -0,0 +1,121 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsRetryableClientErr(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"grpc unavailable", status.Error(codes.Unavailable, "down"), true},
+		{"grpc deadline exceeded", status.Error(codes.DeadlineExceeded, "timeout"), true},
+		{"grpc not found", status.Error(codes.NotFound, "no such thing"), false},
+		{"wrapped context.DeadlineExceeded", fmt.Errorf("query: %w", context.DeadlineExceeded), true},
+		{"bare context.DeadlineExceeded", context.DeadlineExceeded, true},
+		{"connection refused", errors.New("dial tcp: connection refused"), true},
+		{"connection reset", errors.New("read: connection reset by peer"), true},
+		{"EOF", errors.New("unexpected EOF"), true},
+		{"text deadline exceeded", errors.New("context deadline exceeded"), true},
+		{"unrelated error", errors.New("invalid request"), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isRetryableClientErr(tc.err); got != tc.want {
+				t.Errorf("isRetryableClientErr(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func newTestExecutor(providers ...string) *Executor {
+	clients := make([]*ExecutorClient, 0, len(providers))
+	for _, p := range providers {
+		clients = append(clients, &ExecutorClient{Provider: p})
+	}
+	return &Executor{greenfieldClients: clients}
+}
+
+func TestWithClientFailsOverOnRetryableError(t *testing.T) {
+	e := newTestExecutor("a", "b")
+	var called []string
+	err := e.withClient("method", func(c *ExecutorClient) error {
+		called = append(called, c.Provider)
+		if c.Provider == "a" {
+			return errors.New("unexpected EOF")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withClient returned err: %v", err)
+	}
+	if got := called; len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("withClient called clients %v, want [a b]", got)
+	}
+}
+
+func TestWithClientStopsOnNonRetryableError(t *testing.T) {
+	e := newTestExecutor("a", "b")
+	wantErr := errors.New("invalid request")
+	var called []string
+	err := e.withClient("method", func(c *ExecutorClient) error {
+		called = append(called, c.Provider)
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("withClient returned %v, want %v", err, wantErr)
+	}
+	if len(called) != 1 {
+		t.Fatalf("withClient called clients %v, want exactly [a]", called)
+	}
+}
+
+func TestWithClientQuarantinesAfterThreshold(t *testing.T) {
+	e := newTestExecutor("a", "b")
+	a := e.greenfieldClients[0]
+	for i := 0; i < clientFailureThreshold; i++ {
+		_ = e.withClient("method", func(c *ExecutorClient) error {
+			if c.Provider == "a" {
+				return errors.New("unexpected EOF")
+			}
+			return nil
+		})
+	}
+	if !e.isQuarantined(a) {
+		t.Fatalf("client a should be quarantined after %d consecutive failures", clientFailureThreshold)
+	}
+
+	var called []string
+	err := e.withClient("method", func(c *ExecutorClient) error {
+		called = append(called, c.Provider)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withClient returned err: %v", err)
+	}
+	if len(called) != 1 || called[0] != "b" {
+		t.Fatalf("withClient called clients %v, want only the non-quarantined [b]", called)
+	}
+}
+
+func TestWithClientAllQuarantinedReturnsError(t *testing.T) {
+	e := newTestExecutor("a")
+	e.greenfieldClients[0].quarantinedUntil = time.Now().Add(time.Minute)
+	err := e.withClient("method", func(c *ExecutorClient) error {
+		t.Fatal("fn should not be called when every client is quarantined")
+		return nil
+	})
+	if err == nil {
+		t.Fatal("withClient should return an error when every client is quarantined")
+	}
+}
@@ -0,0 +1,292 @@
+package executor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bnb-chain/gnfd-challenger/config"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/evmos/ethermint/crypto/ethsecp256k1"
+)
+
+// Signer abstracts over where and how the greenfield-challenger's key material lives,
+// so Executor doesn't need to know whether a tx is signed with an in-memory key or a
+// remote/air-gapped one.
+type Signer interface {
+	PubKey() cryptotypes.PubKey
+	Sign(msg []byte) ([]byte, error)
+	Address() string
+}
+
+// NewSigner builds the Signer backend selected by cfg.KeyType.
+func NewSigner(cfg *config.GreenfieldConfig) (Signer, error) {
+	switch cfg.KeyType {
+	case config.KeyTypeAWSPrivateKey:
+		return newLocalSignerFromAWSSecret(cfg)
+	case config.KeyTypeGCPSecretManager:
+		return newLocalSignerFromGCPSecret(cfg)
+	case config.KeyTypeVault:
+		return newVaultSigner(cfg)
+	case config.KeyTypeRemoteSigner:
+		return newRemoteSigner(cfg)
+	default:
+		return newLocalSigner(cfg.PrivateKey)
+	}
+}
+
+// localSigner keeps the ethsecp256k1 private key in memory, as the hex and
+// AWS/GCP-secret-backed configurations have always done.
+type localSigner struct {
+	privKey *ethsecp256k1.PrivKey
+}
+
+func newLocalSigner(hexPrivateKey string) (*localSigner, error) {
+	privKey, err := HexToEthSecp256k1PrivKey(hexPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &localSigner{privKey: privKey}, nil
+}
+
+func (s *localSigner) PubKey() cryptotypes.PubKey {
+	return s.privKey.PubKey()
+}
+
+func (s *localSigner) Sign(msg []byte) ([]byte, error) {
+	return s.privKey.Sign(msg)
+}
+
+func (s *localSigner) Address() string {
+	return s.privKey.PubKey().Address().String()
+}
+
+type remoteSecretPrivateKey struct {
+	PrivateKey string `json:"private_key"`
+}
+
+func newLocalSignerFromAWSSecret(cfg *config.GreenfieldConfig) (Signer, error) {
+	result, err := config.GetSecret(cfg.AWSSecretName, cfg.AWSRegion)
+	if err != nil {
+		return nil, err
+	}
+	var secret remoteSecretPrivateKey
+	if err := json.Unmarshal([]byte(result), &secret); err != nil {
+		return nil, err
+	}
+	return newLocalSigner(secret.PrivateKey)
+}
+
+func newLocalSignerFromGCPSecret(cfg *config.GreenfieldConfig) (Signer, error) {
+	result, err := config.GetGCPSecret(cfg.GCPProjectId, cfg.GCPSecretName)
+	if err != nil {
+		return nil, err
+	}
+	var secret remoteSecretPrivateKey
+	if err := json.Unmarshal([]byte(result), &secret); err != nil {
+		return nil, err
+	}
+	return newLocalSigner(secret.PrivateKey)
+}
+
+// vaultSigner signs through a HashiCorp Vault Transit engine, so the private key never
+// leaves Vault - only signatures cross the wire.
+type vaultSigner struct {
+	httpClient *http.Client
+	addr       string
+	token      string
+	keyName    string
+	pubKey     cryptotypes.PubKey
+}
+
+func newVaultSigner(cfg *config.GreenfieldConfig) (Signer, error) {
+	s := &vaultSigner{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		addr:       cfg.VaultAddress,
+		token:      cfg.VaultToken,
+		keyName:    cfg.VaultTransitKeyName,
+	}
+	pubKey, err := s.fetchPubKey()
+	if err != nil {
+		return nil, err
+	}
+	s.pubKey = pubKey
+	return s, nil
+}
+
+func (s *vaultSigner) vaultRequest(method, path string, body, out interface{}) error {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		reqBody, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/v1/%s", s.addr, path), bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", s.token)
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vault request to %s failed with status %s", path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (s *vaultSigner) fetchPubKey() (cryptotypes.PubKey, error) {
+	var out struct {
+		Data struct {
+			Keys map[string]struct {
+				PublicKey string `json:"public_key"`
+			} `json:"keys"`
+			LatestVersion int `json:"latest_version"`
+		} `json:"data"`
+	}
+	if err := s.vaultRequest(http.MethodGet, fmt.Sprintf("transit/keys/%s", s.keyName), nil, &out); err != nil {
+		return nil, err
+	}
+	latest, ok := out.Data.Keys[fmt.Sprintf("%d", out.Data.LatestVersion)]
+	if !ok {
+		return nil, fmt.Errorf("vault transit key %s has no version %d", s.keyName, out.Data.LatestVersion)
+	}
+	rawPubKey, err := base64.StdEncoding.DecodeString(latest.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return ethSecp256k1PubKeyFromBytes(rawPubKey), nil
+}
+
+func (s *vaultSigner) PubKey() cryptotypes.PubKey {
+	return s.pubKey
+}
+
+func (s *vaultSigner) Address() string {
+	return s.pubKey.Address().String()
+}
+
+func (s *vaultSigner) Sign(msg []byte) ([]byte, error) {
+	var out struct {
+		Data struct {
+			Signature string `json:"signature"`
+		} `json:"data"`
+	}
+	err := s.vaultRequest(http.MethodPost, fmt.Sprintf("transit/sign/%s", s.keyName), map[string]interface{}{
+		"input":     base64.StdEncoding.EncodeToString(msg),
+		"prehashed": false,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return decodeVaultSignature(out.Data.Signature)
+}
+
+// remoteSigner delegates signing to a co-located, air-gapped process over a plain TCP
+// socket using a bespoke newline-delimited JSON request/response protocol of our own -
+// it is not wire-compatible with tendermint's privval SignerListenEndpoint (no
+// SecretConnection handshake, no protobuf framing), so it cannot be pointed at tmkms or
+// other privval-speaking signers. It only works against a peer implementing this same
+// protocol.
+type remoteSigner struct {
+	endpoint string
+	pubKey   cryptotypes.PubKey
+}
+
+func newRemoteSigner(cfg *config.GreenfieldConfig) (Signer, error) {
+	pubKey, err := fetchRemoteSignerPubKey(cfg.RemoteSignerAddress)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteSigner{
+		endpoint: cfg.RemoteSignerAddress,
+		pubKey:   pubKey,
+	}, nil
+}
+
+func (s *remoteSigner) PubKey() cryptotypes.PubKey {
+	return s.pubKey
+}
+
+func (s *remoteSigner) Address() string {
+	return s.pubKey.Address().String()
+}
+
+func (s *remoteSigner) Sign(msg []byte) ([]byte, error) {
+	return requestRemoteSignerSignature(s.endpoint, msg)
+}
+
+// ethSecp256k1PubKeyFromBytes wraps a raw compressed secp256k1 public key as returned
+// by Vault/the remote signer in ethermint's PubKey type.
+func ethSecp256k1PubKeyFromBytes(raw []byte) cryptotypes.PubKey {
+	return &ethsecp256k1.PubKey{Key: raw}
+}
+
+// decodeVaultSignature strips Vault Transit's "vault:v<version>:" envelope off a
+// base64-encoded signature and decodes the rest.
+func decodeVaultSignature(sig string) ([]byte, error) {
+	if idx := strings.LastIndex(sig, ":"); idx != -1 {
+		sig = sig[idx+1:]
+	}
+	return base64.StdEncoding.DecodeString(sig)
+}
+
+// remoteSignerRequest/Response implement this package's own small JSON-over-TCP
+// protocol: the remote process holds the key and answers each pub_key/sign request on
+// its own short-lived TCP connection, so the key never has to live on this host. This is a custom
+// protocol, not tendermint's privval socket protocol - it is not wire-compatible with
+// tmkms or other privval-speaking signers.
+type remoteSignerRequest struct {
+	Op      string `json:"op"`
+	Payload []byte `json:"payload,omitempty"`
+}
+
+type remoteSignerResponse struct {
+	PubKey    []byte `json:"pub_key,omitempty"`
+	Signature []byte `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+func remoteSignerRoundTrip(addr string, req remoteSignerRequest) (*remoteSignerResponse, error) {
+	conn, err := net.DialTimeout("tcp", addr, 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, err
+	}
+	var resp remoteSignerResponse
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("remote signer error: %s", resp.Error)
+	}
+	return &resp, nil
+}
+
+func fetchRemoteSignerPubKey(addr string) (cryptotypes.PubKey, error) {
+	resp, err := remoteSignerRoundTrip(addr, remoteSignerRequest{Op: "pub_key"})
+	if err != nil {
+		return nil, err
+	}
+	return ethSecp256k1PubKeyFromBytes(resp.PubKey), nil
+}
+
+func requestRemoteSignerSignature(addr string, msg []byte) ([]byte, error) {
+	resp, err := remoteSignerRoundTrip(addr, remoteSignerRequest{Op: "sign", Payload: msg})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
@@ -0,0 +1,64 @@
+package executor
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/bnb-chain/gnfd-challenger/config"
+)
+
+func TestDecodeVaultSignature(t *testing.T) {
+	raw := []byte{1, 2, 3, 4}
+	encoded := base64.StdEncoding.EncodeToString(raw)
+
+	cases := []struct {
+		name string
+		sig  string
+	}{
+		{"bare base64", encoded},
+		{"vault envelope", "vault:v1:" + encoded},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := decodeVaultSignature(tc.sig)
+			if err != nil {
+				t.Fatalf("decodeVaultSignature(%q) returned err: %v", tc.sig, err)
+			}
+			if string(got) != string(raw) {
+				t.Errorf("decodeVaultSignature(%q) = %v, want %v", tc.sig, got, raw)
+			}
+		})
+	}
+}
+
+func TestNewSignerDispatchesOnKeyType(t *testing.T) {
+	hexKey := "3b7955d25389d46fb2fdd8b4e51e0daee3d6bb1f1c0f9d2cfd5a92e3e6e5f6a0"
+
+	signer, err := NewSigner(&config.GreenfieldConfig{KeyType: config.KeyTypeLocalPrivateKey, PrivateKey: hexKey})
+	if err != nil {
+		t.Fatalf("NewSigner(local) returned err: %v", err)
+	}
+	if _, ok := signer.(*localSigner); !ok {
+		t.Errorf("NewSigner(local) = %T, want *localSigner", signer)
+	}
+
+	signer, err = NewSigner(&config.GreenfieldConfig{PrivateKey: hexKey})
+	if err != nil {
+		t.Fatalf("NewSigner(unset KeyType) returned err: %v", err)
+	}
+	if _, ok := signer.(*localSigner); !ok {
+		t.Errorf("NewSigner(unset KeyType) = %T, want *localSigner (default)", signer)
+	}
+
+	if _, err := NewSigner(&config.GreenfieldConfig{KeyType: config.KeyTypeAWSPrivateKey}); err == nil {
+		t.Error("NewSigner(aws) with no reachable AWS secret should return an error, got nil")
+	}
+
+	if _, err := NewSigner(&config.GreenfieldConfig{KeyType: config.KeyTypeVault, VaultAddress: "http://127.0.0.1:0"}); err == nil {
+		t.Error("NewSigner(vault) with no reachable Vault should return an error, got nil")
+	}
+
+	if _, err := NewSigner(&config.GreenfieldConfig{KeyType: config.KeyTypeRemoteSigner, RemoteSignerAddress: "127.0.0.1:0"}); err == nil {
+		t.Error("NewSigner(remote signer) with no reachable peer should return an error, got nil")
+	}
+}
@@ -0,0 +1,23 @@
+package executor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBlockTimeSkew(t *testing.T) {
+	t.Run("zero LatestBlockTime", func(t *testing.T) {
+		c := &ExecutorClient{}
+		if got := blockTimeSkew(c); got != 0 {
+			t.Errorf("blockTimeSkew() = %v, want 0", got)
+		}
+	})
+
+	t.Run("stale LatestBlockTime", func(t *testing.T) {
+		c := &ExecutorClient{LatestBlockTime: time.Now().Add(-time.Minute)}
+		skew := blockTimeSkew(c)
+		if skew < 59*time.Second || skew > time.Hour {
+			t.Errorf("blockTimeSkew() = %v, want ~1m", skew)
+		}
+	})
+}
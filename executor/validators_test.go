@@ -0,0 +1,77 @@
+package executor
+
+import (
+	"context"
+	"testing"
+
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// fakeValidatorsClient is a minimal rpcclient.Client that only implements Validators;
+// embedding the interface lets it satisfy every other method without reimplementing
+// tendermint's large RPC surface, since queryAllValidators only ever calls Validators.
+type fakeValidatorsClient struct {
+	rpcclient.Client
+	pages [][]*tmtypes.Validator
+	total []int
+	calls int
+}
+
+func (f *fakeValidatorsClient) Validators(_ context.Context, _ *int64, page, _ *int) (*ctypes.ResultValidators, error) {
+	idx := f.calls
+	f.calls++
+	return &ctypes.ResultValidators{
+		Validators: f.pages[idx],
+		Count:      len(f.pages[idx]),
+		Total:      f.total[idx],
+	}, nil
+}
+
+func validator(addr string) *tmtypes.Validator {
+	return &tmtypes.Validator{Address: tmtypes.Address(addr)}
+}
+
+func TestQueryAllValidatorsPaginates(t *testing.T) {
+	client := &fakeValidatorsClient{
+		pages: [][]*tmtypes.Validator{
+			{validator("aaaaaaaaaaaaaaaaaaaa"), validator("bbbbbbbbbbbbbbbbbbbb")},
+			{validator("cccccccccccccccccccc")},
+		},
+		total: []int{3, 3},
+	}
+	all, err := queryAllValidators(client, nil)
+	if err != nil {
+		t.Fatalf("queryAllValidators returned err: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("queryAllValidators returned %d validators, want 3", len(all))
+	}
+	if client.calls != 2 {
+		t.Fatalf("queryAllValidators made %d calls, want 2", client.calls)
+	}
+}
+
+func TestQueryAllValidatorsRestartsOnTotalChange(t *testing.T) {
+	client := &fakeValidatorsClient{
+		pages: [][]*tmtypes.Validator{
+			{validator("aaaaaaaaaaaaaaaaaaaa")},
+			// total grew mid-pagination: queryAllValidators must discard what it has
+			// collected, including this page, and restart from page 1 against the new total.
+			{validator("bbbbbbbbbbbbbbbbbbbb")},
+			{validator("dddddddddddddddddddd"), validator("eeeeeeeeeeeeeeeeeeee"), validator("ffffffffffffffffffff")},
+		},
+		total: []int{2, 3, 3},
+	}
+	all, err := queryAllValidators(client, nil)
+	if err != nil {
+		t.Fatalf("queryAllValidators returned err: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("queryAllValidators returned %d validators, want 3", len(all))
+	}
+	if client.calls != 3 {
+		t.Fatalf("queryAllValidators made %d calls, want 3", client.calls)
+	}
+}
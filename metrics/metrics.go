@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/bnb-chain/gnfd-challenger/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "gnfd_challenger"
+	subsystem = "executor"
+
+	readHeaderTimeout = 5 * time.Second
+	readTimeout       = 10 * time.Second
+)
+
+var (
+	// DataSeedLatestHeight is the latest block height last reported by each
+	// configured data seed.
+	DataSeedLatestHeight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "data_seed_latest_height",
+		Help:      "Latest block height last reported by a configured data seed.",
+	}, []string{"provider"})
+
+	// RequestLatencySeconds measures how long a request against a data seed took.
+	RequestLatencySeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "request_latency_seconds",
+		Help:      "Latency of requests made against a data seed.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"provider", "method"})
+
+	// RequestFailuresTotal counts failed requests against a data seed.
+	RequestFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "request_failures_total",
+		Help:      "Total number of failed requests against a data seed.",
+	}, []string{"provider", "method"})
+
+	// ActiveClientIndex is the index into the configured data seed pool currently
+	// used to serve requests.
+	ActiveClientIndex = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "active_client_index",
+		Help:      "Index into the configured data seed pool currently used for requests.",
+	})
+
+	// CachedValidatorCount is the size of the locally cached validator set.
+	CachedValidatorCount = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "cached_validator_count",
+		Help:      "Number of validators in the locally cached validator set.",
+	})
+
+	// AccountSequence is the last observed account sequence number, by address.
+	AccountSequence = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "account_sequence",
+		Help:      "Last observed account sequence number, by account address.",
+	}, []string{"address"})
+)
+
+// StartServer starts an HTTP server exposing a Prometheus /metrics endpoint and a
+// /healthz endpoint on addr. healthy is called on every /healthz request and should
+// return false once every configured data seed is quarantined or still catching up.
+// ReadHeaderTimeout/ReadTimeout are set so a slow or idle client can't hold the
+// connection open indefinitely (Slowloris-style).
+func StartServer(addr string, healthy func() bool) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		if !healthy() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logging.Logger.Errorf("metrics server stopped, err=%s", err.Error())
+		}
+	}()
+	return server
+}